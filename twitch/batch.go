@@ -0,0 +1,83 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// maxBatchSize is the most ids/logins Helix accepts in a single id=/login=
+// query per call.
+const maxBatchSize = 100
+
+// maxBatchConcurrency bounds how many chunks of a batched lookup are
+// in flight against Twitch at once.
+const maxBatchConcurrency = 5
+
+// A MultiError collects the errors from the chunks of a batched request
+// that failed. Callers still get back whatever chunks succeeded.
+type MultiError []error
+
+func (m MultiError) Error() string {
+	if len(m) == 1 {
+		return m[0].Error()
+	}
+	msgs := make([]string, len(m))
+	for i, err := range m {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("twitch: %d batch requests failed: %s", len(m), strings.Join(msgs, "; "))
+}
+
+func chunkStrings(values []string, size int) [][]string {
+	var chunks [][]string
+	for len(values) > 0 {
+		n := size
+		if n > len(values) {
+			n = len(values)
+		}
+		chunks = append(chunks, values[:n])
+		values = values[n:]
+	}
+	return chunks
+}
+
+// runBatches splits values into chunks of at most maxBatchSize, runs
+// worker on up to maxBatchConcurrency of them concurrently, and waits for
+// all of them to finish. Workers are responsible for merging their own
+// results into the caller's accumulator under a lock; runBatches only
+// merges errors, returning a MultiError when at least one chunk failed.
+func runBatches(ctx context.Context, values []string, worker func(ctx context.Context, chunk []string) error) error {
+	chunks := chunkStrings(values, maxBatchSize)
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs MultiError
+		sem  = make(chan struct{}, maxBatchConcurrency)
+	)
+
+	for _, chunk := range chunks {
+		chunk := chunk
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := worker(ctx, chunk); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+// TestRunBatchesMergesErrorsButKeepsGoing guards the partial-failure
+// semantics: one chunk failing must not stop the others from running, and
+// the failure must come back as a MultiError callers can inspect.
+func TestRunBatchesMergesErrorsButKeepsGoing(t *testing.T) {
+	values := make([]string, maxBatchSize+5)
+	for i := range values {
+		values[i] = "v"
+	}
+
+	var succeeded int32
+	err := runBatches(context.Background(), values, func(ctx context.Context, chunk []string) error {
+		if len(chunk) != maxBatchSize {
+			return errors.New("boom")
+		}
+		atomic.AddInt32(&succeeded, int32(len(chunk)))
+		return nil
+	})
+
+	var multi MultiError
+	if !errors.As(err, &multi) {
+		t.Fatalf("expected MultiError, got %v (%T)", err, err)
+	}
+	if len(multi) != 1 {
+		t.Fatalf("expected 1 failed chunk, got %d", len(multi))
+	}
+	if got := atomic.LoadInt32(&succeeded); got != maxBatchSize {
+		t.Fatalf("expected the other chunk to still run, got %d items processed", got)
+	}
+}
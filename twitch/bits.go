@@ -0,0 +1,48 @@
+package twitch
+
+import "context"
+
+// BitsService handles communication with the bits related methods of the
+// Twitch Helix API.
+type BitsService service
+
+// A Cheermote describes a tier of a Twitch cheermote.
+type Cheermote struct {
+	Prefix string `json:"prefix"`
+	Tiers  []struct {
+		MinBits int    `json:"min_bits"`
+		ID      string `json:"id"`
+		Color   string `json:"color"`
+	} `json:"tiers"`
+	Type         string `json:"type"`
+	Order        int    `json:"order"`
+	LastUpdated  string `json:"last_updated"`
+	IsCharitable bool   `json:"is_charitable"`
+}
+
+type listCheermotesOptions struct {
+	BroadcasterID string `url:"broadcaster_id,omitempty"`
+}
+
+// Returns a list of Cheermotes that users can use to cheer Bits in any
+// Bits-enabled channel's chat room.
+func (s *BitsService) ListCheermotes(ctx context.Context, broadcasterID string) ([]*Cheermote, *Response, error) {
+	u, err := addOptions("bits/cheermotes", &listCheermotesOptions{BroadcasterID: broadcasterID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out struct {
+		Data []*Cheermote `json:"data"`
+	}
+	resp, err := s.client.Do(ctx, req, &out)
+	if err != nil {
+		return nil, resp, err
+	}
+	return out.Data, resp, nil
+}
@@ -0,0 +1,108 @@
+package twitch
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// APICache memoizes GET responses for a minimum TTL, keyed on method, URL,
+// and the request's auth scope. Twitch aggressively rate-limits, and bots
+// doing stream status polling, user lookup, or game name resolution tend to
+// issue the same lookup many times a minute; this collapses those into one
+// call per TTL window. Concurrent identical requests are collapsed further
+// with singleflight so only one of them ever hits the network.
+type APICache struct {
+	minTTL  time.Duration
+	maxSize int
+
+	mu    sync.Mutex
+	items map[string]cacheEntry
+	order []string
+
+	group singleflight.Group
+}
+
+type cacheEntry struct {
+	resp      *Response
+	body      []byte
+	expiresAt time.Time
+}
+
+// NewAPICache returns an APICache holding up to size entries (no limit if
+// size is zero or less), each served for minTTL before the underlying GET is
+// re-issued. Once size entries are held, storing a new key evicts the
+// oldest-stored one, even if it has not expired yet.
+func NewAPICache(size int, minTTL time.Duration) *APICache {
+	return &APICache{
+		maxSize: size,
+		minTTL:  minTTL,
+		items:   make(map[string]cacheEntry),
+	}
+}
+
+func (c *APICache) fetch(key string, do func() (*Response, []byte, error)) (*Response, []byte, error) {
+	c.mu.Lock()
+	if e, ok := c.items[key]; ok && time.Now().Before(e.expiresAt) {
+		c.mu.Unlock()
+		return e.resp, e.body, nil
+	}
+	c.mu.Unlock()
+
+	v, err, _ := c.group.Do(key, func() (interface{}, error) {
+		resp, body, doErr := do()
+		return cacheEntry{resp: resp, body: body}, doErr
+	})
+
+	// v is always a cacheEntry, even on error: do() can still return a
+	// non-nil *Response (status code, rate-limit headers) alongside an
+	// *ErrorResponse, and callers of Client.Do expect that Response to
+	// survive whether or not a Cache is in play.
+	e := v.(cacheEntry)
+	if err != nil {
+		return e.resp, e.body, err
+	}
+
+	e.expiresAt = time.Now().Add(c.minTTL)
+
+	c.mu.Lock()
+	c.store(key, e)
+	c.mu.Unlock()
+
+	return e.resp, e.body, nil
+}
+
+func (c *APICache) store(key string, e cacheEntry) {
+	if _, exists := c.items[key]; !exists {
+		if c.maxSize > 0 && len(c.order) >= c.maxSize {
+			oldest := c.order[0]
+			c.order = c.order[1:]
+			delete(c.items, oldest)
+		}
+		c.order = append(c.order, key)
+	}
+	c.items[key] = e
+}
+
+// Returns the cache key for req: its method, URL, and the bearer token
+// scoping the response, so cached data for one user is never served to
+// another.
+func cacheKey(req *http.Request) string {
+	return req.Method + " " + req.URL.String() + "|" + req.Header.Get("Authorization")
+}
+
+type noCacheContextKey struct{}
+
+// WithNoCache returns a copy of ctx that bypasses the client's Cache for any
+// request made with it, even if Client.Cache is set.
+func WithNoCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheContextKey{}, true)
+}
+
+func noCache(ctx context.Context) bool {
+	v, _ := ctx.Value(noCacheContextKey{}).(bool)
+	return v
+}
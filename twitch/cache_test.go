@@ -0,0 +1,69 @@
+package twitch
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestAPICacheFetchCollapsesConcurrentCalls guards the singleflight
+// collapse: concurrent fetches for the same key must only hit the
+// underlying do func once.
+func TestAPICacheFetchCollapsesConcurrentCalls(t *testing.T) {
+	c := NewAPICache(10, time.Minute)
+
+	var calls int32
+	do := func() (*Response, []byte, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(10 * time.Millisecond)
+		return &Response{Response: &http.Response{StatusCode: http.StatusOK}}, []byte(`{}`), nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := c.fetch("key", do); err != nil {
+				t.Errorf("fetch: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected exactly 1 underlying call, got %d", got)
+	}
+}
+
+// TestAPICacheFetchPreservesResponseOnError guards against fetch discarding
+// the *Response on an error path, which broke the resp, err :=
+// s.client.Do(...); if err != nil { return nil, resp, err } contract every
+// service method relies on once a Cache was configured. It also checks that
+// error responses are never stored.
+func TestAPICacheFetchPreservesResponseOnError(t *testing.T) {
+	c := NewAPICache(10, time.Minute)
+
+	wantResp := &Response{Response: &http.Response{StatusCode: http.StatusTooManyRequests}}
+	wantErr := errors.New("rate limited")
+
+	resp, body, err := c.fetch("key", func() (*Response, []byte, error) {
+		return wantResp, nil, wantErr
+	})
+	if err != wantErr {
+		t.Fatalf("fetch error = %v, want %v", err, wantErr)
+	}
+	if resp != wantResp {
+		t.Fatalf("fetch response = %v, want %v", resp, wantResp)
+	}
+	if body != nil {
+		t.Fatalf("fetch body = %v, want nil", body)
+	}
+
+	if _, ok := c.items["key"]; ok {
+		t.Fatalf("error responses should not be cached")
+	}
+}
@@ -0,0 +1,49 @@
+package twitch
+
+import "context"
+
+// ChatService handles communication with the chat related methods of the
+// Twitch Helix API.
+type ChatService service
+
+// A BadgeSet describes one versioned chat badge.
+type BadgeSet struct {
+	SetID    string `json:"set_id"`
+	Versions []struct {
+		ID         string `json:"id"`
+		ImageURL1x string `json:"image_url_1x"`
+		ImageURL2x string `json:"image_url_2x"`
+		ImageURL4x string `json:"image_url_4x"`
+	} `json:"versions"`
+}
+
+type getBadgesOptions struct {
+	BroadcasterID string `url:"broadcaster_id,omitempty"`
+}
+
+// Returns the chat badges for a broadcaster's channel. Pass an empty
+// broadcasterID to fetch the global badge set instead.
+func (s *ChatService) GetBadges(ctx context.Context, broadcasterID string) ([]*BadgeSet, *Response, error) {
+	u := "chat/badges/global"
+	if broadcasterID != "" {
+		var err error
+		u, err = addOptions("chat/badges", &getBadgesOptions{BroadcasterID: broadcasterID})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	req, err := s.client.NewRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out struct {
+		Data []*BadgeSet `json:"data"`
+	}
+	resp, err := s.client.Do(ctx, req, &out)
+	if err != nil {
+		return nil, resp, err
+	}
+	return out.Data, resp, nil
+}
@@ -0,0 +1,88 @@
+package twitch
+
+import (
+	"context"
+	"net/http"
+)
+
+// ClipsService handles communication with the clips related methods of the
+// Twitch Helix API.
+type ClipsService service
+
+// A Clip is a short clipped section of a broadcaster's stream.
+type Clip struct {
+	ID            string `json:"id"`
+	URL           string `json:"url"`
+	EmbedURL      string `json:"embed_url"`
+	BroadcasterID string `json:"broadcaster_id"`
+	CreatorID     string `json:"creator_id"`
+	VideoID       string `json:"video_id"`
+	GameID        string `json:"game_id"`
+	Title         string `json:"title"`
+	ViewCount     int    `json:"view_count"`
+	CreatedAt     string `json:"created_at"`
+}
+
+// ClipsListOptions specifies the optional parameters to the List method.
+type ClipsListOptions struct {
+	BroadcasterID string `url:"broadcaster_id,omitempty"`
+	GameID        string `url:"game_id,omitempty"`
+
+	ListOptions
+}
+
+type clipsPage struct {
+	Data       []*Clip    `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// NextCursor implements Paginated.
+func (p *clipsPage) NextCursor() string {
+	return p.Pagination.Cursor
+}
+
+// Returns clips for the broadcaster or game identified in opt.
+func (s *ClipsService) List(ctx context.Context, opt *ClipsListOptions) ([]*Clip, *Pagination, *Response, error) {
+	u, err := addOptions("clips", opt)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var page clipsPage
+	resp, err := s.client.Do(ctx, req, &page)
+	if err != nil {
+		return nil, nil, resp, err
+	}
+	return page.Data, &page.Pagination, resp, nil
+}
+
+// ListAll fetches every page of clips for the broadcaster or game identified
+// in opt, invoking fn with each page's clips. It stops early if fn returns
+// false, and sleeps until the rate-limit window resets if a page exhausts
+// it, so large batch jobs don't 429.
+func (s *ClipsService) ListAll(ctx context.Context, opt *ClipsListOptions, fn func([]*Clip) (bool, error)) error {
+	if opt == nil {
+		opt = &ClipsListOptions{}
+	}
+
+	pager := s.client.Iterate(func(ctx context.Context, cursor string) (*http.Request, error) {
+		o := *opt
+		o.Cursor = cursor
+		u, err := addOptions("clips", &o)
+		if err != nil {
+			return nil, err
+		}
+		return s.client.NewRequest(ctx, "GET", u, nil)
+	})
+
+	return pager.Each(ctx, func() Paginated {
+		return &clipsPage{}
+	}, func(page Paginated) (bool, error) {
+		return fn(page.(*clipsPage).Data)
+	})
+}
@@ -0,0 +1,355 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const eventSubWebsocketURL = "wss://eventsub.wss.twitch.tv/ws"
+
+// An EventSubHandler receives a decoded EventSub notification payload for
+// the subscription type it was registered for.
+type EventSubHandler func(ctx context.Context, subscriptionType string, event json.RawMessage)
+
+// eventSubSubscription records the arguments a Subscribe call was made
+// with, so the subscription can be re-created against a new session after
+// a keepalive-timeout reconnect loses it.
+type eventSubSubscription struct {
+	subscriptionType string
+	version          string
+	condition        interface{}
+	handler          EventSubHandler
+}
+
+// EventSub manages a single EventSub WebSocket connection and the
+// subscriptions made over it. Use it instead of polling Helix endpoints for
+// things like follows, subs, and stream status changes.
+type EventSub struct {
+	client *Client
+
+	mu               sync.Mutex
+	conn             *websocket.Conn
+	sessionID        string
+	handlers         map[string][]EventSubHandler
+	subs             []*eventSubSubscription
+	needsResubscribe bool
+
+	keepaliveTimeout time.Duration
+	lastURL          string
+}
+
+func newEventSub(c *Client) *EventSub {
+	return &EventSub{
+		client:           c,
+		handlers:         make(map[string][]EventSubHandler),
+		keepaliveTimeout: 10 * time.Second,
+	}
+}
+
+// Connect dials the EventSub WebSocket transport and processes messages
+// until ctx is canceled or the connection is lost without a usable
+// reconnect URL. Twitch periodically recycles connections for load
+// balancing by sending a session_reconnect message; Connect loops to
+// follow the handoff rather than recursing through connect/readLoop, so a
+// long-lived process doesn't grow its goroutine's stack with every
+// reconnect. Callers typically run it in its own goroutine.
+func (e *EventSub) Connect(ctx context.Context) error {
+	url := eventSubWebsocketURL
+	for {
+		nextURL, err := e.connect(ctx, url)
+		if err != nil {
+			return err
+		}
+		if nextURL == "" {
+			return nil
+		}
+		url = nextURL
+	}
+}
+
+// connect dials url and runs the read loop on it until the connection is
+// lost or Twitch hands off to a reconnect URL, which it returns so Connect
+// can dial it next. It never calls itself or Connect, so each hop through a
+// reconnect reuses the same stack frame.
+func (e *EventSub) connect(ctx context.Context, url string) (string, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("twitch: eventsub dial: %w", err)
+	}
+
+	e.mu.Lock()
+	old := e.conn
+	e.conn = conn
+	e.lastURL = url
+	e.mu.Unlock()
+	if old != nil {
+		old.Close()
+	}
+
+	e.refreshDeadline(conn)
+
+	return e.readLoop(ctx)
+}
+
+// refreshDeadline pushes conn's read deadline out to twice the keepalive
+// interval Twitch advertised in session_welcome, as Twitch recommends, so a
+// silent stall (dead TCP connection, no keepalive, no clean close) surfaces
+// as a read error instead of blocking forever.
+func (e *EventSub) refreshDeadline(conn *websocket.Conn) {
+	e.mu.Lock()
+	timeout := e.keepaliveTimeout
+	e.mu.Unlock()
+
+	conn.SetReadDeadline(time.Now().Add(2 * timeout))
+}
+
+// reconnectAfterTimeout redials the last known EventSub URL after the read
+// deadline expires with no session_reconnect message. Unlike the graceful
+// session_reconnect handoff, this starts a brand new session that does not
+// carry the old one's subscriptions over, so it marks needsResubscribe;
+// once the new session's session_welcome arrives, readLoop re-creates every
+// subscription made via Subscribe against it.
+func (e *EventSub) reconnectAfterTimeout(ctx context.Context) error {
+	e.mu.Lock()
+	url := e.lastURL
+	old := e.conn
+	e.mu.Unlock()
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return fmt.Errorf("twitch: eventsub reconnect after keepalive timeout: %w", err)
+	}
+
+	e.mu.Lock()
+	e.conn = conn
+	e.sessionID = ""
+	e.needsResubscribe = true
+	e.mu.Unlock()
+
+	e.refreshDeadline(conn)
+
+	if old != nil {
+		old.Close()
+	}
+	return nil
+}
+
+// resubscribeAll re-creates every subscription made via Subscribe against
+// the current session. It's run after a keepalive-timeout reconnect, whose
+// new session starts with none of the old session's subscriptions.
+func (e *EventSub) resubscribeAll(ctx context.Context) error {
+	e.mu.Lock()
+	subs := append([]*eventSubSubscription(nil), e.subs...)
+	sessionID := e.sessionID
+	e.mu.Unlock()
+
+	var errs MultiError
+	for _, sub := range subs {
+		if err := e.doSubscribe(ctx, sessionID, sub.subscriptionType, sub.version, sub.condition); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Subscribe registers handler for notifications of subscriptionType and
+// creates the subscription on Twitch, using this EventSub's WebSocket
+// session as the delivery transport. Connect must have established a
+// session before Subscribe is called.
+func (e *EventSub) Subscribe(ctx context.Context, subscriptionType string, version string, condition interface{}, handler EventSubHandler) error {
+	e.mu.Lock()
+	sessionID := e.sessionID
+	e.mu.Unlock()
+
+	if sessionID == "" {
+		return fmt.Errorf("twitch: eventsub: no active session, call Connect first")
+	}
+
+	if err := e.doSubscribe(ctx, sessionID, subscriptionType, version, condition); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	e.handlers[subscriptionType] = append(e.handlers[subscriptionType], handler)
+	e.subs = append(e.subs, &eventSubSubscription{
+		subscriptionType: subscriptionType,
+		version:          version,
+		condition:        condition,
+		handler:          handler,
+	})
+	e.mu.Unlock()
+
+	return nil
+}
+
+// doSubscribe issues the Helix subscription create call binding
+// subscriptionType to sessionID as the websocket transport.
+func (e *EventSub) doSubscribe(ctx context.Context, sessionID, subscriptionType, version string, condition interface{}) error {
+	body := struct {
+		Type      string      `json:"type"`
+		Version   string      `json:"version"`
+		Condition interface{} `json:"condition"`
+		Transport struct {
+			Method    string `json:"method"`
+			SessionID string `json:"session_id"`
+		} `json:"transport"`
+	}{
+		Type:      subscriptionType,
+		Version:   version,
+		Condition: condition,
+	}
+	body.Transport.Method = "websocket"
+	body.Transport.SessionID = sessionID
+
+	req, err := e.client.NewRequest(ctx, "POST", "eventsub/subscriptions", body)
+	if err != nil {
+		return err
+	}
+	_, err = e.client.Do(ctx, req, nil)
+	return err
+}
+
+type eventSubEnvelope struct {
+	Metadata struct {
+		MessageType      string `json:"message_type"`
+		SubscriptionType string `json:"subscription_type"`
+	} `json:"metadata"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// readLoop processes messages off the connection dialed by connect until
+// it's lost, returning the reconnect URL from a session_reconnect message
+// so connect/Connect can dial it without recursing.
+func (e *EventSub) readLoop(ctx context.Context) (string, error) {
+	for {
+		e.mu.Lock()
+		conn := e.conn
+		e.mu.Unlock()
+
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				if rerr := e.reconnectAfterTimeout(ctx); rerr != nil {
+					return "", rerr
+				}
+				continue
+			}
+			return "", fmt.Errorf("twitch: eventsub read: %w", err)
+		}
+
+		e.refreshDeadline(conn)
+
+		var env eventSubEnvelope
+		if err = json.Unmarshal(data, &env); err != nil {
+			continue
+		}
+
+		switch env.Metadata.MessageType {
+		case "session_welcome", "session_keepalive":
+			e.handleSession(env.Payload, env.Metadata.MessageType)
+			// session_welcome carries Twitch's advertised keepalive interval,
+			// which refreshDeadline above used the prior (possibly default)
+			// value for; re-apply it now that it's known.
+			e.refreshDeadline(conn)
+
+			if env.Metadata.MessageType == "session_welcome" {
+				e.mu.Lock()
+				needsResubscribe := e.needsResubscribe
+				e.needsResubscribe = false
+				e.mu.Unlock()
+
+				if needsResubscribe {
+					if err = e.resubscribeAll(ctx); err != nil {
+						return "", err
+					}
+				}
+			}
+		case "session_reconnect":
+			url, err := parseReconnectURL(env.Payload)
+			if err != nil {
+				return "", err
+			}
+			return url, nil
+		case "notification":
+			e.handleNotification(ctx, env.Metadata.SubscriptionType, env.Payload)
+		case "revocation":
+			e.handleNotification(ctx, env.Metadata.SubscriptionType, env.Payload)
+		}
+	}
+}
+
+func (e *EventSub) handleSession(payload json.RawMessage, messageType string) {
+	if messageType != "session_welcome" {
+		return
+	}
+
+	var p struct {
+		Session struct {
+			ID                      string `json:"id"`
+			KeepaliveTimeoutSeconds int    `json:"keepalive_timeout_seconds"`
+		} `json:"session"`
+	}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return
+	}
+
+	e.mu.Lock()
+	e.sessionID = p.Session.ID
+	if p.Session.KeepaliveTimeoutSeconds > 0 {
+		e.keepaliveTimeout = time.Duration(p.Session.KeepaliveTimeoutSeconds) * time.Second
+	}
+	e.mu.Unlock()
+}
+
+// parseReconnectURL extracts the reconnect_url Twitch includes in a
+// session_reconnect message's payload.
+func parseReconnectURL(payload json.RawMessage) (string, error) {
+	var p struct {
+		Session struct {
+			ReconnectURL string `json:"reconnect_url"`
+		} `json:"session"`
+	}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return "", err
+	}
+	return p.Session.ReconnectURL, nil
+}
+
+func (e *EventSub) handleNotification(ctx context.Context, subscriptionType string, payload json.RawMessage) {
+	var p struct {
+		Event json.RawMessage `json:"event"`
+	}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return
+	}
+
+	e.mu.Lock()
+	handlers := append([]EventSubHandler(nil), e.handlers[subscriptionType]...)
+	e.mu.Unlock()
+
+	for _, h := range handlers {
+		h(ctx, subscriptionType, p.Event)
+	}
+}
+
+// Close terminates the underlying WebSocket connection.
+func (e *EventSub) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn == nil {
+		return nil
+	}
+	err := e.conn.Close()
+	e.conn = nil
+	return err
+}
@@ -0,0 +1,58 @@
+package twitch
+
+import (
+	"context"
+	"sync"
+)
+
+// GamesService handles communication with the games related methods of the
+// Twitch Helix API.
+type GamesService service
+
+// A Game is a category Twitch streams can be classified under.
+type Game struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	BoxArtURL string `json:"box_art_url"`
+}
+
+// Returns the games with the given names, transparently splitting them into
+// chunks of 100 (Helix's max per call) and fetching the chunks
+// concurrently. Games from chunks that succeeded are returned even if
+// others failed; a non-nil error is always a MultiError in that case.
+func (s *GamesService) GetByName(ctx context.Context, names ...string) ([]*Game, error) {
+	return s.get(ctx, "name", names)
+}
+
+// Returns the games with the given IDs, chunked and fetched the same way as
+// GetByName.
+func (s *GamesService) GetByID(ctx context.Context, ids ...string) ([]*Game, error) {
+	return s.get(ctx, "id", ids)
+}
+
+func (s *GamesService) get(ctx context.Context, param string, values []string) ([]*Game, error) {
+	var (
+		mu    sync.Mutex
+		games []*Game
+	)
+
+	err := runBatches(ctx, values, func(ctx context.Context, chunk []string) error {
+		req, err := s.client.NewRequest(ctx, "GET", "games?"+joinQuery(param, chunk), nil)
+		if err != nil {
+			return err
+		}
+
+		var out struct {
+			Data []*Game `json:"data"`
+		}
+		if _, err = s.client.Do(ctx, req, &out); err != nil {
+			return err
+		}
+
+		mu.Lock()
+		games = append(games, out.Data...)
+		mu.Unlock()
+		return nil
+	})
+	return games, err
+}
@@ -0,0 +1,87 @@
+package twitch
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Response wraps the underlying HTTP response with the rate-limit metadata
+// Helix reports on every call, parsed from the Ratelimit-Limit/
+// Ratelimit-Remaining/Ratelimit-Reset headers.
+type Response struct {
+	*http.Response
+
+	// RateLimit is the total number of calls allowed in the current window.
+	RateLimit int
+
+	// RateRemaining is the number of calls left in the current window.
+	RateRemaining int
+
+	// RateReset is when the current rate-limit window resets.
+	RateReset time.Time
+}
+
+func newResponse(r *http.Response) *Response {
+	resp := &Response{Response: r}
+	resp.RateLimit, _ = strconv.Atoi(r.Header.Get("Ratelimit-Limit"))
+	resp.RateRemaining, _ = strconv.Atoi(r.Header.Get("Ratelimit-Remaining"))
+	if resetUnix, err := strconv.Atoi(r.Header.Get("Ratelimit-Reset")); err == nil {
+		resp.RateReset = time.Unix(int64(resetUnix), 0)
+	}
+	return resp
+}
+
+// Paginated is implemented by the decode target passed to Pager.Each. It
+// reports the cursor Helix returned for the next page, found at
+// pagination.cursor in the response body.
+type Paginated interface {
+	NextCursor() string
+}
+
+// Pager drives repeated calls to a List-style Helix endpoint, re-issuing the
+// request with the previous page's cursor until Helix stops returning one.
+type Pager struct {
+	client *Client
+	newReq func(ctx context.Context, cursor string) (*http.Request, error)
+}
+
+// Iterate returns a Pager that calls newReq to build each page's request,
+// passing it the cursor of the previous page (empty for the first page).
+func (c *Client) Iterate(newReq func(ctx context.Context, cursor string) (*http.Request, error)) *Pager {
+	return &Pager{client: c, newReq: newReq}
+}
+
+// Each calls newPage to get a fresh decode target for every page (it must
+// implement Paginated), decodes the page into it, and invokes fn with the
+// decoded page. A fresh value per page matters: Helix's last page comes
+// back with an empty "pagination":{} object, so the cursor key is simply
+// absent from the JSON rather than present-and-empty, and a reused decode
+// target would keep the previous page's cursor forever. Iteration stops at
+// the first error, when fn returns false, or once a page's cursor comes
+// back empty.
+func (p *Pager) Each(ctx context.Context, newPage func() Paginated, fn func(page Paginated) (bool, error)) error {
+	cursor := ""
+	for {
+		req, err := p.newReq(ctx, cursor)
+		if err != nil {
+			return err
+		}
+
+		page := newPage()
+		if _, err = p.client.Do(ctx, req, page); err != nil {
+			return err
+		}
+
+		cont, err := fn(page)
+		if err != nil {
+			return err
+		}
+
+		cursor = page.NextCursor()
+		if !cont || cursor == "" {
+			return nil
+		}
+	}
+}
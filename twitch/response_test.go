@@ -0,0 +1,55 @@
+package twitch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+type pagerTestPage struct {
+	Pagination Pagination `json:"pagination"`
+}
+
+// NextCursor implements Paginated.
+func (p *pagerTestPage) NextCursor() string {
+	return p.Pagination.Cursor
+}
+
+// TestPagerEachStopsOnEmptyPagination guards against the bug where Each
+// reused one decode target across pages: Helix's last page omits the
+// pagination.cursor key entirely rather than sending it empty, and a reused
+// target kept the previous page's cursor forever, looping indefinitely.
+func TestPagerEachStopsOnEmptyPagination(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			w.Write([]byte(`{"pagination":{"cursor":"abc"}}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(nil, "")
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	pager := c.Iterate(func(ctx context.Context, cursor string) (*http.Request, error) {
+		return c.NewRequest(ctx, "GET", "things", nil)
+	})
+
+	err := pager.Each(context.Background(), func() Paginated {
+		return &pagerTestPage{}
+	}, func(page Paginated) (bool, error) {
+		return true, nil
+	})
+	if err != nil {
+		t.Fatalf("Each returned error: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 requests (one per page), got %d", calls)
+	}
+}
@@ -0,0 +1,90 @@
+package twitch
+
+import (
+	"context"
+	"net/http"
+)
+
+// searchChannelsOptions combines the search query with the shared pagination
+// options so addOptions can encode both into a single query string.
+type searchChannelsOptions struct {
+	Query string `url:"query"`
+	ListOptions
+}
+
+// SearchService handles communication with the search related methods of
+// the Twitch Helix API.
+type SearchService service
+
+// A Channel is a search result describing a channel.
+type Channel struct {
+	ID               string `json:"id"`
+	BroadcasterLogin string `json:"broadcaster_login"`
+	DisplayName      string `json:"display_name"`
+	GameID           string `json:"game_id"`
+	IsLive           bool   `json:"is_live"`
+	Title            string `json:"title"`
+}
+
+type channelsPage struct {
+	Data       []*Channel `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// NextCursor implements Paginated.
+func (p *channelsPage) NextCursor() string {
+	return p.Pagination.Cursor
+}
+
+// Searches for channels matching query.
+func (s *SearchService) Channels(ctx context.Context, query string, opt *ListOptions) ([]*Channel, *Pagination, *Response, error) {
+	u, err := addOptions("search/channels", searchChannelsOpt(query, opt))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var page channelsPage
+	resp, err := s.client.Do(ctx, req, &page)
+	if err != nil {
+		return nil, nil, resp, err
+	}
+	return page.Data, &page.Pagination, resp, nil
+}
+
+// ListAllChannels fetches every page of channel search results for query,
+// invoking fn with each page's channels. It stops early if fn returns
+// false.
+func (s *SearchService) ListAllChannels(ctx context.Context, query string, opt *ListOptions, fn func([]*Channel) (bool, error)) error {
+	pager := s.client.Iterate(func(ctx context.Context, cursor string) (*http.Request, error) {
+		o := ListOptions{}
+		if opt != nil {
+			o = *opt
+		}
+		o.Cursor = cursor
+
+		u, err := addOptions("search/channels", searchChannelsOpt(query, &o))
+		if err != nil {
+			return nil, err
+		}
+		return s.client.NewRequest(ctx, "GET", u, nil)
+	})
+
+	return pager.Each(ctx, func() Paginated {
+		return &channelsPage{}
+	}, func(page Paginated) (bool, error) {
+		return fn(page.(*channelsPage).Data)
+	})
+}
+
+func searchChannelsOpt(query string, opt *ListOptions) *searchChannelsOptions {
+	o := &searchChannelsOptions{Query: query}
+	if opt != nil {
+		o.ListOptions = *opt
+	}
+	return o
+}
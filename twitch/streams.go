@@ -0,0 +1,68 @@
+package twitch
+
+import (
+	"context"
+	"sync"
+)
+
+// StreamsService handles communication with the streams related methods of
+// the Twitch Helix API.
+type StreamsService service
+
+// A Stream is a broadcaster's currently live stream.
+type Stream struct {
+	ID           string   `json:"id"`
+	UserID       string   `json:"user_id"`
+	UserLogin    string   `json:"user_login"`
+	UserName     string   `json:"user_name"`
+	GameID       string   `json:"game_id"`
+	Type         string   `json:"type"`
+	Title        string   `json:"title"`
+	ViewerCount  int      `json:"viewer_count"`
+	StartedAt    string   `json:"started_at"`
+	Language     string   `json:"language"`
+	ThumbnailURL string   `json:"thumbnail_url"`
+	TagIDs       []string `json:"tag_ids"`
+}
+
+// Returns the live streams for the given user logins, transparently
+// splitting them into chunks of 100 (Helix's max per call) and fetching the
+// chunks concurrently. Streams from chunks that succeeded are returned even
+// if others failed; a non-nil error is always a MultiError in that case.
+// Users who are not currently live are simply absent from the results.
+func (s *StreamsService) GetByUser(ctx context.Context, logins ...string) ([]*Stream, error) {
+	return s.get(ctx, "user_login", logins)
+}
+
+// Returns the live streams for the given user IDs, chunked and fetched the
+// same way as GetByUser.
+func (s *StreamsService) GetByUserID(ctx context.Context, ids ...string) ([]*Stream, error) {
+	return s.get(ctx, "user_id", ids)
+}
+
+func (s *StreamsService) get(ctx context.Context, param string, values []string) ([]*Stream, error) {
+	var (
+		mu      sync.Mutex
+		streams []*Stream
+	)
+
+	err := runBatches(ctx, values, func(ctx context.Context, chunk []string) error {
+		req, err := s.client.NewRequest(ctx, "GET", "streams?"+joinQuery(param, chunk), nil)
+		if err != nil {
+			return err
+		}
+
+		var out struct {
+			Data []*Stream `json:"data"`
+		}
+		if _, err = s.client.Do(ctx, req, &out); err != nil {
+			return err
+		}
+
+		mu.Lock()
+		streams = append(streams, out.Data...)
+		mu.Unlock()
+		return nil
+	})
+	return streams, err
+}
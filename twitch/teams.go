@@ -0,0 +1,63 @@
+package twitch
+
+import "context"
+
+// TeamsService handles communication with the teams related methods of the
+// Twitch Helix API.
+type TeamsService service
+
+// A Team is a group of broadcasters on Twitch.
+type Team struct {
+	ID              string `json:"id"`
+	TeamName        string `json:"team_name"`
+	TeamDisplayName string `json:"team_display_name"`
+	Info            string `json:"info"`
+	ThumbnailURL    string `json:"thumbnail_url"`
+	Users           []struct {
+		UserID    string `json:"user_id"`
+		UserLogin string `json:"user_login"`
+		UserName  string `json:"user_name"`
+	} `json:"users"`
+}
+
+type teamsOptions struct {
+	Name string `url:"name,omitempty"`
+	ID   string `url:"id,omitempty"`
+}
+
+// Returns a team by its name.
+func (s *TeamsService) GetByName(ctx context.Context, name string) (*Team, *Response, error) {
+	u, err := addOptions("teams", &teamsOptions{Name: name})
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.get(ctx, u)
+}
+
+// Returns a team by its ID.
+func (s *TeamsService) GetByID(ctx context.Context, id string) (*Team, *Response, error) {
+	u, err := addOptions("teams", &teamsOptions{ID: id})
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.get(ctx, u)
+}
+
+func (s *TeamsService) get(ctx context.Context, path string) (*Team, *Response, error) {
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out struct {
+		Data []*Team `json:"data"`
+	}
+	resp, err := s.client.Do(ctx, req, &out)
+	if err != nil {
+		return nil, resp, err
+	}
+	if len(out.Data) == 0 {
+		return nil, resp, nil
+	}
+	return out.Data[0], resp, nil
+}
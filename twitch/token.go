@@ -0,0 +1,261 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// A TokenSource supplies the bearer token sent with every Client request.
+// Implementations must be safe for concurrent use.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// A Refresher is implemented by TokenSources that can drop their cached
+// token and fetch a new one. Client.Do calls Refresh once and retries the
+// request when a TokenSource implements it and a request comes back 401.
+type Refresher interface {
+	Refresh(ctx context.Context) error
+}
+
+// StaticTokenSource is a TokenSource that always returns the same token. It
+// cannot be refreshed; a 401 is returned to the caller as-is.
+type StaticTokenSource struct {
+	AccessToken string
+}
+
+// NewStaticTokenSource returns a TokenSource for a pre-obtained, non-expiring
+// token such as one issued for local development.
+func NewStaticTokenSource(accessToken string) *StaticTokenSource {
+	return &StaticTokenSource{AccessToken: accessToken}
+}
+
+// Token implements TokenSource.
+func (s *StaticTokenSource) Token(ctx context.Context) (string, error) {
+	return s.AccessToken, nil
+}
+
+// UserTokenSource is a TokenSource for a user access token, refreshed on
+// demand using a refresh token against https://id.twitch.tv/oauth2/token.
+type UserTokenSource struct {
+	ClientID     string
+	ClientSecret string
+
+	mu           sync.Mutex
+	accessToken  string
+	refreshToken string
+	expiry       time.Time
+
+	sf singleflight.Group
+}
+
+// NewUserTokenSource returns a TokenSource that starts from accessToken and
+// refreshToken and transparently refreshes itself once accessToken expires.
+func NewUserTokenSource(clientID, clientSecret, accessToken, refreshToken string) *UserTokenSource {
+	return &UserTokenSource{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		accessToken:  accessToken,
+		refreshToken: refreshToken,
+	}
+}
+
+// Token implements TokenSource, refreshing the cached token once it is
+// within a minute of expiring.
+func (s *UserTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	needsRefresh := s.accessToken == "" || (!s.expiry.IsZero() && time.Now().After(s.expiry.Add(-time.Minute)))
+	s.mu.Unlock()
+
+	if needsRefresh {
+		if err := s.Refresh(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accessToken, nil
+}
+
+// Refresh implements Refresher, exchanging the stored refresh token for a
+// new access token. Twitch invalidates the old refresh token on each use, so
+// concurrent callers must not race to spend it: Refresh collapses concurrent
+// calls through a singleflight.Group, so only one refresh request is ever in
+// flight and every waiting caller observes its result.
+func (s *UserTokenSource) Refresh(ctx context.Context) error {
+	_, err, _ := s.sf.Do("refresh", func() (interface{}, error) {
+		s.mu.Lock()
+		refreshToken := s.refreshToken
+		s.mu.Unlock()
+
+		v := url.Values{}
+		v.Set("client_id", s.ClientID)
+		v.Set("client_secret", s.ClientSecret)
+		v.Set("grant_type", "refresh_token")
+		v.Set("refresh_token", refreshToken)
+
+		tr, err := requestOAuthToken(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+
+		s.mu.Lock()
+		s.accessToken = tr.AccessToken
+		if tr.RefreshToken != "" {
+			s.refreshToken = tr.RefreshToken
+		}
+		s.expiry = time.Now().Add(time.Duration(tr.ExpiresIn) * time.Second)
+		s.mu.Unlock()
+		return nil, nil
+	})
+	return err
+}
+
+// AppTokenSource is a TokenSource for an app access token, obtained and
+// refreshed using the client_credentials grant.
+type AppTokenSource struct {
+	ClientID     string
+	ClientSecret string
+
+	mu          sync.Mutex
+	accessToken string
+	expiry      time.Time
+
+	sf singleflight.Group
+}
+
+// NewAppTokenSource returns a TokenSource that mints and refreshes its own
+// app access token via client_credentials.
+func NewAppTokenSource(clientID, clientSecret string) *AppTokenSource {
+	return &AppTokenSource{ClientID: clientID, ClientSecret: clientSecret}
+}
+
+// Token implements TokenSource, refreshing the cached token once it is
+// within a minute of expiring.
+func (s *AppTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	needsRefresh := s.accessToken == "" || (!s.expiry.IsZero() && time.Now().After(s.expiry.Add(-time.Minute)))
+	s.mu.Unlock()
+
+	if needsRefresh {
+		if err := s.Refresh(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accessToken, nil
+}
+
+// Refresh implements Refresher, minting a fresh app access token. Concurrent
+// calls are collapsed through a singleflight.Group so two callers racing on
+// an expired token don't mint two tokens and silently discard one.
+func (s *AppTokenSource) Refresh(ctx context.Context) error {
+	_, err, _ := s.sf.Do("refresh", func() (interface{}, error) {
+		v := url.Values{}
+		v.Set("client_id", s.ClientID)
+		v.Set("client_secret", s.ClientSecret)
+		v.Set("grant_type", "client_credentials")
+
+		tr, err := requestOAuthToken(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+
+		s.mu.Lock()
+		s.accessToken = tr.AccessToken
+		s.expiry = time.Now().Add(time.Duration(tr.ExpiresIn) * time.Second)
+		s.mu.Unlock()
+		return nil, nil
+	})
+	return err
+}
+
+type oauthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+func requestOAuthToken(ctx context.Context, v url.Values) (*oauthTokenResponse, error) {
+	req, err := http.NewRequest(http.MethodPost, idTokenURL, strings.NewReader(v.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err = checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var tr oauthTokenResponse
+	if err = json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return nil, err
+	}
+	return &tr, nil
+}
+
+// A ValidatedToken describes the identity and scopes behind a token, as
+// returned by https://id.twitch.tv/oauth2/validate.
+type ValidatedToken struct {
+	ClientID  string   `json:"client_id"`
+	Login     string   `json:"login"`
+	UserID    string   `json:"user_id"`
+	Scopes    []string `json:"scopes"`
+	ExpiresIn int      `json:"expires_in"`
+}
+
+const validateTokenURL = "https://id.twitch.tv/oauth2/validate"
+
+// ValidateToken hits the Twitch token validation endpoint for the client's
+// current token and returns the identity and scopes behind it, so callers
+// can key caches and permission checks off the validated login/user ID
+// rather than trusting the token blindly.
+func (c *Client) ValidateToken(ctx context.Context) (*ValidatedToken, error) {
+	if c.TokenSource == nil {
+		return nil, errNoTokenSource
+	}
+	token, err := c.TokenSource.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, validateTokenURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Authorization", "OAuth "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err = checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var vt ValidatedToken
+	if err = json.NewDecoder(resp.Body).Decode(&vt); err != nil {
+		return nil, err
+	}
+	return &vt, nil
+}
@@ -0,0 +1,88 @@
+package twitch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// countingOAuthTransport stands in for id.twitch.tv, counting how many
+// token requests actually went out.
+type countingOAuthTransport struct {
+	calls int32
+}
+
+func (t *countingOAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&t.calls, 1)
+	body, _ := json.Marshal(oauthTokenResponse{
+		AccessToken:  "access-token",
+		RefreshToken: "rotated-refresh-token",
+		ExpiresIn:    3600,
+	})
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// TestUserTokenSourceRefreshIsSerialized guards against the race where two
+// goroutines both observe an expired token and both call Refresh, spending
+// the same refresh token twice against Twitch's strict rotation.
+func TestUserTokenSourceRefreshIsSerialized(t *testing.T) {
+	orig := http.DefaultClient.Transport
+	rt := &countingOAuthTransport{}
+	http.DefaultClient.Transport = rt
+	defer func() { http.DefaultClient.Transport = orig }()
+
+	s := NewUserTokenSource("client-id", "client-secret", "", "refresh-token")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.Token(context.Background()); err != nil {
+				t.Errorf("Token: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&rt.calls); got != 1 {
+		t.Fatalf("expected exactly 1 refresh request, got %d", got)
+	}
+}
+
+// TestAppTokenSourceRefreshIsSerialized is the AppTokenSource equivalent of
+// TestUserTokenSourceRefreshIsSerialized.
+func TestAppTokenSourceRefreshIsSerialized(t *testing.T) {
+	orig := http.DefaultClient.Transport
+	rt := &countingOAuthTransport{}
+	http.DefaultClient.Transport = rt
+	defer func() { http.DefaultClient.Transport = orig }()
+
+	s := NewAppTokenSource("client-id", "client-secret")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.Token(context.Background()); err != nil {
+				t.Errorf("Token: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&rt.calls); got != 1 {
+		t.Fatalf("expected exactly 1 refresh request, got %d", got)
+	}
+}
@@ -4,24 +4,27 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"reflect"
+	"sync"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
 
 const (
-	libraryVersion = "2"
-	rootURL        = "https://api.twitch.tv/kraken/"
+	libraryVersion = "3"
+	rootURL        = "https://api.twitch.tv/helix/"
+	idTokenURL     = "https://id.twitch.tv/oauth2/token"
 	userAgent      = "go-twitch/" + libraryVersion
-	mediaType      = "application/vnd.twitchtv.v5+json"
 )
 
-// A Client manages communication with the Twitch API.
+// A Client manages communication with the Twitch Helix API.
 type Client struct {
 	client *http.Client
 
@@ -34,17 +37,33 @@ type Client struct {
 	// Twitch client ID.
 	ClientID string
 
-	// Token that authenticates the requests made to the Twitch API.
-	AccessToken string
+	// TokenSource supplies the bearer token sent with every request. Use
+	// NewStaticTokenSource, NewUserTokenSource, or NewAppTokenSource, or
+	// provide your own implementation.
+	TokenSource TokenSource
+
+	// Cache, if set, memoizes GET responses. See NewAPICache.
+	Cache *APICache
+
+	rateMu        sync.Mutex
+	rateRemaining int
+	rateReset     time.Time
 
 	// Services used for talking to different parts of the Twitch API.
+	//
+	// Ingests has no Helix equivalent (ingest servers are no longer exposed
+	// by the API) and was dropped in the migration from Kraken.
 	Bits    *BitsService
 	Chat    *ChatService
 	Clips   *ClipsService
 	Games   *GamesService
-	Ingests *IngestsService
 	Search  *SearchService
+	Streams *StreamsService
 	Teams   *TeamsService
+	Users   *UsersService
+
+	// EventSub manages the client's EventSub WebSocket subscriptions.
+	EventSub *EventSub
 
 	common service
 }
@@ -57,13 +76,16 @@ type service struct {
 // support pagination.
 type ListOptions struct {
 	// Tells the server where to start fetching the next set of results.
-	Cursor string `url:"cursor,omitempty"`
+	Cursor string `url:"after,omitempty"`
 
 	// Maximum number of results per page.
-	Limit int `url:"limit,omitempty"`
+	Limit int `url:"first,omitempty"`
+}
 
-	// Offset of the set of results.
-	Offset int `url:"offset,omitempty"`
+// Pagination carries the cursor Helix returns for List-style endpoints so
+// the next page can be requested via ListOptions.Cursor.
+type Pagination struct {
+	Cursor string `json:"cursor"`
 }
 
 // Adds the parameters in opt as URL query parameters to s. opt must be a struct
@@ -88,10 +110,21 @@ func addOptions(s string, opt interface{}) (string, error) {
 	return u.String(), nil
 }
 
-// Returns a new Twitch API client.
+// Builds a query string repeating param once per value, e.g. joinQuery("id",
+// []string{"1", "2"}) returns "id=1&id=2". Helix takes repeated parameters
+// rather than a comma-joined list for its batch lookup endpoints.
+func joinQuery(param string, values []string) string {
+	v := url.Values{}
+	for _, value := range values {
+		v.Add(param, value)
+	}
+	return v.Encode()
+}
+
+// Returns a new Twitch Helix API client.
 //
 // If a nil httpClient is provided, http.DefaultClient will be used. To use API
-// methods which require authentication, set the AccessToken field of the
+// methods which require authentication, set the TokenSource field of the
 // returned client.
 func NewClient(httpClient *http.Client, clientID string) *Client {
 	if httpClient == nil {
@@ -112,9 +145,11 @@ func NewClient(httpClient *http.Client, clientID string) *Client {
 	c.Chat = (*ChatService)(&c.common)
 	c.Clips = (*ClipsService)(&c.common)
 	c.Games = (*GamesService)(&c.common)
-	c.Ingests = (*IngestsService)(&c.common)
 	c.Search = (*SearchService)(&c.common)
+	c.Streams = (*StreamsService)(&c.common)
 	c.Teams = (*TeamsService)(&c.common)
+	c.Users = (*UsersService)(&c.common)
+	c.EventSub = newEventSub(c)
 
 	return c
 }
@@ -125,7 +160,7 @@ func NewClient(httpClient *http.Client, clientID string) *Client {
 //
 // If not nil, the value pointed to by body is JSON encoded and included as the
 // request body.
-func (c *Client) NewRequest(method, path string, body interface{}) (*http.Request, error) {
+func (c *Client) NewRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
 	rel, err := url.Parse(path)
 	if err != nil {
 		return nil, err
@@ -149,52 +184,183 @@ func (c *Client) NewRequest(method, path string, body interface{}) (*http.Reques
 	if c.ClientID != "" {
 		req.Header.Set("Client-ID", c.ClientID)
 	}
-	if c.AccessToken != "" {
-		req.Header.Set("Authorization", "OAuth "+c.AccessToken)
+	if c.TokenSource != nil {
+		token, err := c.TokenSource.Token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
 	}
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
-	req.Header.Set("Accept", mediaType)
 	req.Header.Set("User-Agent", c.UserAgent)
 
 	return req, nil
 }
 
+// Blocks until the rate limit window tracked from the last response has
+// reset, if the previous response reported no calls remaining.
+func (c *Client) waitForRateLimit(ctx context.Context) error {
+	c.rateMu.Lock()
+	remaining, reset := c.rateRemaining, c.rateReset
+	c.rateMu.Unlock()
+
+	if remaining > 0 || reset.IsZero() {
+		return nil
+	}
+
+	d := time.Until(reset)
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func (c *Client) updateRateLimit(resp *Response) {
+	if resp.RateReset.IsZero() {
+		return
+	}
+	c.rateMu.Lock()
+	c.rateRemaining = resp.RateRemaining
+	c.rateReset = resp.RateReset
+	c.rateMu.Unlock()
+}
+
 // Do sends an API request and returns the API response.
 //
 // The API response is JSON decoded and stored in the value pointed to by r, or
-// returned as an error if an API error has occurred.
+// returned as an error if an API error has occurred. GET requests are served
+// from Client.Cache when one is set, unless ctx was derived from WithNoCache.
 //
 // The provided ctx must not be nil. If it is canceled or times out, ctx.Err()
 // will be returned.
-func (c *Client) Do(ctx context.Context, req *http.Request, r interface{}) (*http.Response, error) {
+func (c *Client) Do(ctx context.Context, req *http.Request, r interface{}) (*Response, error) {
+	if c.Cache != nil && req.Method == http.MethodGet && !noCache(ctx) {
+		resp, body, err := c.Cache.fetch(cacheKey(req), func() (*Response, []byte, error) {
+			return c.doWithRetry(ctx, req)
+		})
+		if err != nil {
+			return resp, err
+		}
+		return resp, decodeBody(body, r)
+	}
+
+	resp, body, err := c.doWithRetry(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	return resp, decodeBody(body, r)
+}
+
+func decodeBody(body []byte, r interface{}) error {
+	if r == nil || len(body) == 0 {
+		return nil
+	}
+	return json.Unmarshal(body, r)
+}
+
+// Sends req and, if it comes back 401 and the client's TokenSource can
+// refresh itself, refreshes the token once and retries the request.
+func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*Response, []byte, error) {
+	resp, body, err := c.fetch(ctx, req)
+	if err == nil {
+		return resp, body, nil
+	}
+
+	errResp, ok := err.(*ErrorResponse)
+	if !ok || errResp.Response.StatusCode != http.StatusUnauthorized {
+		return resp, body, err
+	}
+
+	refresher, ok := c.TokenSource.(Refresher)
+	if !ok {
+		return resp, body, err
+	}
+	if rerr := refresher.Refresh(ctx); rerr != nil {
+		return resp, body, err
+	}
+
+	retryReq, rerr := cloneRequest(req)
+	if rerr != nil {
+		return resp, body, err
+	}
+	if c.TokenSource != nil {
+		token, terr := c.TokenSource.Token(ctx)
+		if terr != nil {
+			return resp, body, err
+		}
+		if token != "" {
+			retryReq.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
+	return c.fetch(ctx, retryReq)
+}
+
+// Clones req, including its body if it came from one of the body types
+// http.NewRequest knows how to rewind (bytes.Buffer, bytes.Reader,
+// strings.Reader).
+func cloneRequest(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.GetBody == nil {
+		return clone, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	clone.Body = ioutil.NopCloser(body)
+	return clone, nil
+}
+
+// fetch sends req over the wire once, honoring the tracked rate limit, and
+// returns the decoded response metadata along with the raw response body.
+func (c *Client) fetch(ctx context.Context, req *http.Request) (*Response, []byte, error) {
+	if err := c.waitForRateLimit(ctx); err != nil {
+		return nil, nil, err
+	}
+
 	req = req.WithContext(ctx)
 
-	resp, err := c.client.Do(req)
+	httpResp, err := c.client.Do(req)
 	if err != nil {
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
+			return nil, nil, ctx.Err()
 		default:
 		}
-		return nil, err
+		return nil, nil, err
 	}
-	defer resp.Body.Close()
+	defer httpResp.Body.Close()
 
-	if err = checkResponse(resp); err != nil {
-		return resp, err
+	resp := newResponse(httpResp)
+	c.updateRateLimit(resp)
+
+	if err = checkResponse(httpResp); err != nil {
+		return resp, nil, err
 	}
 
-	if r != nil {
-		err = json.NewDecoder(resp.Body).Decode(r)
-		if err == io.EOF {
-			err = nil
-		}
+	body, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return resp, nil, err
 	}
-	return resp, err
+	return resp, body, nil
 }
 
+var errNoTokenSource = errors.New("twitch: client has no TokenSource set")
+
 // An ErrorResponse reports an error caused by an API request.
 type ErrorResponse struct {
 	// HTTP response that cause this error.
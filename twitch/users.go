@@ -0,0 +1,64 @@
+package twitch
+
+import (
+	"context"
+	"sync"
+)
+
+// UsersService handles communication with the users related methods of the
+// Twitch Helix API.
+type UsersService service
+
+// A User is a Twitch user account.
+type User struct {
+	ID              string `json:"id"`
+	Login           string `json:"login"`
+	DisplayName     string `json:"display_name"`
+	Type            string `json:"type"`
+	BroadcasterType string `json:"broadcaster_type"`
+	Description     string `json:"description"`
+	ProfileImageURL string `json:"profile_image_url"`
+	ViewCount       int    `json:"view_count"`
+	CreatedAt       string `json:"created_at"`
+}
+
+// Returns the users with the given logins, transparently splitting them
+// into chunks of 100 (Helix's max per call) and fetching the chunks
+// concurrently. Users from chunks that succeeded are returned even if
+// others failed; a non-nil error is always a MultiError in that case.
+func (s *UsersService) GetByLogin(ctx context.Context, logins ...string) ([]*User, error) {
+	return s.get(ctx, "login", logins)
+}
+
+// Returns the users with the given IDs, chunked and fetched the same way as
+// GetByLogin.
+func (s *UsersService) GetByID(ctx context.Context, ids ...string) ([]*User, error) {
+	return s.get(ctx, "id", ids)
+}
+
+func (s *UsersService) get(ctx context.Context, param string, values []string) ([]*User, error) {
+	var (
+		mu    sync.Mutex
+		users []*User
+	)
+
+	err := runBatches(ctx, values, func(ctx context.Context, chunk []string) error {
+		req, err := s.client.NewRequest(ctx, "GET", "users?"+joinQuery(param, chunk), nil)
+		if err != nil {
+			return err
+		}
+
+		var out struct {
+			Data []*User `json:"data"`
+		}
+		if _, err = s.client.Do(ctx, req, &out); err != nil {
+			return err
+		}
+
+		mu.Lock()
+		users = append(users, out.Data...)
+		mu.Unlock()
+		return nil
+	})
+	return users, err
+}